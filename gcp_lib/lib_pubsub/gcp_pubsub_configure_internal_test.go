@@ -0,0 +1,59 @@
+package gcppubsub
+
+import (
+	"testing"
+
+	"cloud.google.com/go/pubsub"
+)
+
+// These exercise Topic.Configure directly against a bare *pubsub.Topic,
+// rather than through a fake server, since the only thing under test is how
+// Configure merges into PublishSettings.
+
+func TestTopicConfigurePreservesUnsetThresholds(t *testing.T) {
+	top := &Topic{t: &pubsub.Topic{PublishSettings: pubsub.DefaultPublishSettings}}
+
+	top.Configure(PublisherSettings{CountThreshold: 50})
+
+	got := top.t.PublishSettings
+	if got.CountThreshold != 50 {
+		t.Errorf("CountThreshold = %d, want 50", got.CountThreshold)
+	}
+	if got.DelayThreshold != pubsub.DefaultPublishSettings.DelayThreshold {
+		t.Errorf("DelayThreshold = %v, want preserved default %v", got.DelayThreshold, pubsub.DefaultPublishSettings.DelayThreshold)
+	}
+	if got.ByteThreshold != pubsub.DefaultPublishSettings.ByteThreshold {
+		t.Errorf("ByteThreshold = %d, want preserved default %d", got.ByteThreshold, pubsub.DefaultPublishSettings.ByteThreshold)
+	}
+	if got.NumGoroutines != pubsub.DefaultPublishSettings.NumGoroutines {
+		t.Errorf("NumGoroutines = %d, want preserved default %d", got.NumGoroutines, pubsub.DefaultPublishSettings.NumGoroutines)
+	}
+	if got.Timeout != pubsub.DefaultPublishSettings.Timeout {
+		t.Errorf("Timeout = %v, want preserved default %v (not exposed by PublisherSettings)", got.Timeout, pubsub.DefaultPublishSettings.Timeout)
+	}
+}
+
+func TestTopicConfigureOverridesSetThresholds(t *testing.T) {
+	top := &Topic{t: &pubsub.Topic{PublishSettings: pubsub.DefaultPublishSettings}}
+
+	top.Configure(PublisherSettings{
+		CountThreshold: 1,
+		ByteThreshold:  2,
+		NumGoroutines:  3,
+		FlowControlSettings: FlowControlSettings{
+			MaxOutstandingMessages: 4,
+			LimitExceededBehavior:  SignalError,
+		},
+	})
+
+	got := top.t.PublishSettings
+	if got.CountThreshold != 1 || got.ByteThreshold != 2 || got.NumGoroutines != 3 {
+		t.Errorf("PublishSettings = %+v, want CountThreshold=1, ByteThreshold=2, NumGoroutines=3", got)
+	}
+	if got.FlowControlSettings.MaxOutstandingMessages != 4 {
+		t.Errorf("MaxOutstandingMessages = %d, want 4", got.FlowControlSettings.MaxOutstandingMessages)
+	}
+	if got.FlowControlSettings.LimitExceededBehavior != pubsub.FlowControlSignalError {
+		t.Errorf("LimitExceededBehavior = %v, want %v", got.FlowControlSettings.LimitExceededBehavior, pubsub.FlowControlSignalError)
+	}
+}
@@ -0,0 +1,164 @@
+package gcppubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/iam"
+	iampb "google.golang.org/genproto/googleapis/iam/v1"
+)
+
+// IAMPolicy manages the IAM policy of a Topic or Subscription.
+type IAMPolicy struct {
+	h *iam.Handle
+}
+
+// IAM returns a handle for managing the topic's IAM policy.
+func (t *Topic) IAM() *IAMPolicy {
+	if t.t == nil {
+		return &IAMPolicy{}
+	}
+	return &IAMPolicy{h: t.t.IAM()}
+}
+
+// IAM returns a handle for managing the subscription's IAM policy.
+func (s *Subscription) IAM() *IAMPolicy {
+	if s.s == nil {
+		return &IAMPolicy{}
+	}
+	return &IAMPolicy{h: s.s.IAM()}
+}
+
+// GetPolicy fetches the resource's current IAM policy.
+func (p *IAMPolicy) GetPolicy(ctx context.Context) (*Policy, error) {
+	if p.h == nil {
+		return nil, errors.New("invalid IAM handle")
+	}
+
+	pol, err := p.h.Policy(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get IAM policy: %w", err)
+	}
+
+	return newPolicy(pol), nil
+}
+
+// SetPolicy replaces the resource's IAM policy with policy. The call is
+// rejected if policy's etag no longer matches the resource's current
+// policy, i.e. if the policy was modified concurrently since it was
+// fetched with GetPolicy.
+func (p *IAMPolicy) SetPolicy(ctx context.Context, policy *Policy) error {
+	if p.h == nil {
+		return errors.New("invalid IAM handle")
+	}
+
+	if err := p.h.SetPolicy(ctx, policy.toIAM()); err != nil {
+		return fmt.Errorf("failed to set IAM policy: %w", err)
+	}
+
+	return nil
+}
+
+// TestPermissions reports which of perms the caller holds on the resource.
+func (p *IAMPolicy) TestPermissions(ctx context.Context, perms []string) ([]string, error) {
+	if p.h == nil {
+		return nil, errors.New("invalid IAM handle")
+	}
+
+	granted, err := p.h.TestPermissions(ctx, perms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to test IAM permissions: %w", err)
+	}
+
+	return granted, nil
+}
+
+// Policy is a role-to-member-set IAM policy. It preserves the etag of the
+// policy it was fetched from so a later SetPolicy is safely rejected if the
+// resource's policy changed in the meantime.
+type Policy struct {
+	// Bindings maps each IAM role (e.g. "roles/pubsub.publisher") to the set
+	// of members granted that role.
+	Bindings map[string][]string
+
+	etag []byte
+}
+
+// newPolicy converts the upstream policy fetched by IAMPolicy.GetPolicy into
+// our role -> member map representation, keeping its etag for round-tripping
+// through SetPolicy.
+func newPolicy(p *iam.Policy) *Policy {
+	policy := &Policy{Bindings: make(map[string][]string)}
+
+	for _, role := range p.Roles() {
+		policy.Bindings[string(role)] = append([]string(nil), p.Members(role)...)
+	}
+
+	if p.InternalProto != nil {
+		policy.etag = p.InternalProto.GetEtag()
+	}
+
+	return policy
+}
+
+// toIAM converts policy back into the upstream client's representation,
+// carrying over the etag captured when it was fetched.
+func (pol *Policy) toIAM() *iam.Policy {
+	out := &iam.Policy{InternalProto: &iampb.Policy{Etag: pol.etag}}
+
+	for role, members := range pol.Bindings {
+		for _, member := range members {
+			out.Add(member, iam.RoleName(role))
+		}
+	}
+
+	return out
+}
+
+// AddBinding grants role to each of members, in addition to any existing
+// members already holding that role.
+func (pol *Policy) AddBinding(role string, members ...string) {
+	existing := pol.Bindings[role]
+
+	seen := make(map[string]bool, len(existing))
+	for _, m := range existing {
+		seen[m] = true
+	}
+
+	for _, m := range members {
+		if !seen[m] {
+			existing = append(existing, m)
+			seen[m] = true
+		}
+	}
+
+	pol.Bindings[role] = existing
+}
+
+// RemoveBinding revokes role from each of members.
+func (pol *Policy) RemoveBinding(role string, members ...string) {
+	existing := pol.Bindings[role]
+	if len(existing) == 0 {
+		return
+	}
+
+	remove := make(map[string]bool, len(members))
+	for _, m := range members {
+		remove[m] = true
+	}
+
+	kept := existing[:0]
+	for _, m := range existing {
+		if !remove[m] {
+			kept = append(kept, m)
+		}
+	}
+
+	if len(kept) == 0 {
+		delete(pol.Bindings, role)
+		return
+	}
+
+	pol.Bindings[role] = kept
+}
@@ -0,0 +1,115 @@
+package gcppubsub_test
+
+import (
+	"context"
+	"testing"
+
+	gcppubsub "gcp_lib/lib_pubsub"
+)
+
+func TestPolicyAddBinding(t *testing.T) {
+	pol := &gcppubsub.Policy{Bindings: map[string][]string{}}
+
+	pol.AddBinding("roles/pubsub.publisher", "user:a@example.com", "user:b@example.com")
+	pol.AddBinding("roles/pubsub.publisher", "user:b@example.com", "user:c@example.com")
+
+	want := []string{"user:a@example.com", "user:b@example.com", "user:c@example.com"}
+	got := pol.Bindings["roles/pubsub.publisher"]
+	if len(got) != len(want) {
+		t.Fatalf("Bindings[role] = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Bindings[role][%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPolicyRemoveBinding(t *testing.T) {
+	tests := []struct {
+		name     string
+		initial  []string
+		remove   []string
+		want     []string
+		wantGone bool
+	}{
+		{
+			name:    "removes one of several members",
+			initial: []string{"user:a@example.com", "user:b@example.com"},
+			remove:  []string{"user:a@example.com"},
+			want:    []string{"user:b@example.com"},
+		},
+		{
+			name:     "removing the last member deletes the role",
+			initial:  []string{"user:a@example.com"},
+			remove:   []string{"user:a@example.com"},
+			wantGone: true,
+		},
+		{
+			name:    "removing an absent member is a no-op",
+			initial: []string{"user:a@example.com"},
+			remove:  []string{"user:nobody@example.com"},
+			want:    []string{"user:a@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			pol := &gcppubsub.Policy{Bindings: map[string][]string{"roles/pubsub.publisher": tt.initial}}
+
+			pol.RemoveBinding("roles/pubsub.publisher", tt.remove...)
+
+			got, ok := pol.Bindings["roles/pubsub.publisher"]
+			if tt.wantGone {
+				if ok {
+					t.Errorf("Bindings[role] = %v, want role removed entirely", got)
+				}
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Bindings[role] = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("Bindings[role][%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIAMOnInvalidResourceReturnsError(t *testing.T) {
+	client := &gcppubsub.PubSubClient{}
+	ctx := context.Background()
+
+	topicIAM := client.Topic("").IAM()
+	if _, err := topicIAM.GetPolicy(ctx); err == nil {
+		t.Error("Topic(\"\").IAM().GetPolicy() = nil error, want an error for an invalid topic")
+	}
+
+	subIAM := client.Subscription("").IAM()
+	if _, err := subIAM.TestPermissions(ctx, []string{"pubsub.topics.publish"}); err == nil {
+		t.Error("Subscription(\"\").IAM().TestPermissions() = nil error, want an error for an invalid subscription")
+	}
+}
+
+// TestIAMGetPolicyAgainstFakeServer documents a known gap: pstest's in-memory
+// fake does not implement the IAM policy RPCs, so IAMPolicy.GetPolicy/
+// SetPolicy/TestPermissions can't be exercised end-to-end without a real or
+// emulator-backed Pub/Sub service. This only confirms that GetPolicy on a
+// topic handle bound to the fake returns an error instead of succeeding; it
+// does not tell an error from the server apart from a local one.
+func TestIAMGetPolicyAgainstFakeServer(t *testing.T) {
+	srv, client := newTestClient(t)
+	ctx := context.Background()
+
+	if err := srv.CreateTopic("orders"); err != nil {
+		t.Fatalf("srv.CreateTopic() failed: %v", err)
+	}
+	topic := client.Topic("orders")
+
+	if _, err := topic.IAM().GetPolicy(ctx); err == nil {
+		t.Error("GetPolicy() = nil error against a fake with no IAM RPC support, want an error")
+	}
+}
@@ -6,11 +6,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"runtime/debug"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/pubsub"
 	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -20,6 +25,32 @@ var (
 	ErrTopicNotFound = errors.New("topic not found")
 )
 
+var (
+	// ErrRetry asks Receive to nack the message so Pub/Sub redelivers it.
+	// It behaves exactly like any other non-sentinel error; it exists so
+	// Handler implementations can state retry intent explicitly.
+	ErrRetry = errors.New("pubsub: retry message")
+
+	// ErrPermanent marks a message as unprocessable. Receive publishes it to
+	// the subscription's dead-letter topic (if configured via
+	// DeadLetterPolicy) and acks it immediately, instead of nacking it and
+	// waiting for delivery attempts to exhaust.
+	ErrPermanent = errors.New("pubsub: permanent failure")
+
+	// ErrDrop acks and discards the message without redelivering or
+	// dead-lettering it.
+	ErrDrop = errors.New("pubsub: drop message")
+)
+
+// MetricsSink receives counters about subscription processing outcomes.
+// Implementations must be safe for concurrent use, since Receive may invoke
+// them from multiple handler goroutines.
+type MetricsSink interface {
+	// IncPermanentFailures records delta additional messages on subID that
+	// were classified as permanent failures (Handler returned ErrPermanent).
+	IncPermanentFailures(subID string, delta int64)
+}
+
 // Message represents a single data unit transmitted via Pub/Sub
 type Message struct {
 	ID              string            `json:"id"`               // Unique identifier for the message
@@ -27,10 +58,14 @@ type Message struct {
 	Attributes      map[string]string `json:"attributes"`       // Key-Value metadata
 	PublishTime     time.Time         `json:"publish_time"`     // Time at which message was published
 	DeliveryAttempt int               `json:"delivery_attempt"` // Number of delivery attempts
+	OrderingKey     string            `json:"ordering_key"`     // Ordering key the message was published with, if any
 }
 
 // Handler defines the function signature for processing received messages.
-// If the handler returns an error, the message will be nacked and retried.
+// A nil return acks the message. ErrPermanent dead-letters it (published to
+// the subscription's dead-letter topic, if any, then acked) and ErrDrop acks
+// it without redelivery or dead-lettering; any other error, including
+// ErrRetry, nacks the message so Pub/Sub redelivers it.
 type Handler func(ctx context.Context, msg *Message) error
 
 // PubSubClient is the main client for interacting with Google Cloud Pub/Sub.
@@ -42,11 +77,19 @@ type PubSubClient struct {
 // NewPubSubClient creates a new Pub/Sub client for the specified project.
 // The client should be closed after use using the Close() method.
 func NewPubSubClient(ctx context.Context, projectID string) (*PubSubClient, error) {
+	return NewPubSubClientWithOptions(ctx, projectID)
+}
+
+// NewPubSubClientWithOptions creates a new Pub/Sub client for the specified
+// project using the given client options. This is primarily useful for
+// pointing the client at a fake or emulator server in tests (see
+// gcp_lib/lib_pubsub/pstest) rather than a real GCP project.
+func NewPubSubClientWithOptions(ctx context.Context, projectID string, opts ...option.ClientOption) (*PubSubClient, error) {
 	if projectID == "" {
 		return nil, errors.New("project ID cannot be empty")
 	}
 
-	client, err := pubsub.NewClient(ctx, projectID)
+	client, err := pubsub.NewClient(ctx, projectID, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pubsub client: %w", err)
 	}
@@ -66,6 +109,11 @@ func (c *PubSubClient) Close() error {
 // Topic represents a Pub/Sub topic and provides methods for publishing messages.
 type Topic struct {
 	t *pubsub.Topic
+
+	// enableOrdering guards the one-time flip of t.EnableMessageOrdering,
+	// which pubsub.Topic.Publish reads on every call and so can't be set
+	// concurrently from PublishOrdered without a race.
+	enableOrdering sync.Once
 }
 
 // Topic returns a reference to a topic with the given ID.
@@ -97,11 +145,137 @@ func (t *Topic) Exists(ctx context.Context) (bool, error) {
 	return err == nil, err
 }
 
-// Publish publishes a message to the topic with the given data and attributes.
-// It returns the published message ID or an error if the operation fails.
-func (t *Topic) Publish(ctx context.Context, data []byte, attrs map[string]string) (string, error) {
+// PublishResult is a future representing the outcome of an asynchronous
+// publish call started with PublishAsync. It is safe to call Get and Ready
+// from multiple goroutines.
+type PublishResult struct {
+	r   *pubsub.PublishResult
+	err error
+}
+
+// Get blocks until the result is ready and returns the published message ID,
+// or an error if the publish failed or ctx is canceled first.
+func (r *PublishResult) Get(ctx context.Context) (string, error) {
+	if r.r == nil {
+		return "", r.err
+	}
+	return r.r.Get(ctx)
+}
+
+// Ready returns a channel that is closed once the result is available.
+func (r *PublishResult) Ready() <-chan struct{} {
+	if r.r == nil {
+		ch := make(chan struct{})
+		close(ch)
+		return ch
+	}
+	return r.r.Ready()
+}
+
+// LimitExceededBehavior specifies how a topic's publisher reacts when a
+// FlowControlSettings limit is exceeded.
+type LimitExceededBehavior int
+
+const (
+	// Block blocks Publish/PublishAsync calls until there is room under the
+	// configured limits.
+	Block LimitExceededBehavior = iota
+	// Ignore disables flow control entirely.
+	Ignore
+	// SignalError returns an error from Publish/PublishAsync instead of
+	// blocking when a limit is exceeded.
+	SignalError
+)
+
+// FlowControlSettings bounds the messages and bytes a topic's publisher may
+// buffer before applying LimitExceededBehavior.
+type FlowControlSettings struct {
+	// MaxOutstandingMessages is the maximum number of unpublished messages
+	// buffered by the publisher. Zero means no limit.
+	MaxOutstandingMessages int
+
+	// MaxOutstandingBytes is the maximum size in bytes of unpublished
+	// messages buffered by the publisher. Zero means no limit.
+	MaxOutstandingBytes int
+
+	// LimitExceededBehavior controls what happens when a limit above is
+	// exceeded. Defaults to Block.
+	LimitExceededBehavior LimitExceededBehavior
+}
+
+// PublisherSettings configures the batching and flow-control behavior of a
+// Topic's publisher. Apply it with Topic.Configure before the first call to
+// Publish or PublishAsync.
+type PublisherSettings struct {
+	// CountThreshold is the maximum number of messages to batch before
+	// sending. If not set, the underlying client default is used.
+	CountThreshold int
+
+	// DelayThreshold is the maximum time to wait before sending a batch that
+	// hasn't reached CountThreshold or ByteThreshold.
+	DelayThreshold time.Duration
+
+	// ByteThreshold is the maximum size in bytes of a batch before it is
+	// sent.
+	ByteThreshold int
+
+	// NumGoroutines is the number of goroutines used to call the publish
+	// RPC.
+	NumGoroutines int
+
+	// FlowControlSettings bounds the messages buffered by the publisher.
+	FlowControlSettings FlowControlSettings
+}
+
+// Configure applies publisher batching and flow-control settings to the
+// topic. It must be called before the first Publish or PublishAsync call to
+// take effect, mirroring the underlying client's behavior.
+func (t *Topic) Configure(settings PublisherSettings) {
 	if t.t == nil {
-		return "", errors.New("invalid topic")
+		return
+	}
+
+	behavior := pubsub.FlowControlBlock
+	switch settings.FlowControlSettings.LimitExceededBehavior {
+	case Ignore:
+		behavior = pubsub.FlowControlIgnore
+	case SignalError:
+		behavior = pubsub.FlowControlSignalError
+	}
+
+	// Start from the client's current settings so fields PublisherSettings
+	// doesn't expose (Timeout, BufferedByteLimit, compression, ...) are left
+	// alone, and a threshold the caller didn't set keeps the underlying
+	// client default instead of being zeroed out.
+	ps := t.t.PublishSettings
+	if settings.DelayThreshold > 0 {
+		ps.DelayThreshold = settings.DelayThreshold
+	}
+	if settings.CountThreshold > 0 {
+		ps.CountThreshold = settings.CountThreshold
+	}
+	if settings.ByteThreshold > 0 {
+		ps.ByteThreshold = settings.ByteThreshold
+	}
+	if settings.NumGoroutines > 0 {
+		ps.NumGoroutines = settings.NumGoroutines
+	}
+	ps.FlowControlSettings = pubsub.FlowControlSettings{
+		MaxOutstandingMessages: settings.FlowControlSettings.MaxOutstandingMessages,
+		MaxOutstandingBytes:    settings.FlowControlSettings.MaxOutstandingBytes,
+		LimitExceededBehavior:  behavior,
+	}
+
+	t.t.PublishSettings = ps
+}
+
+// PublishAsync publishes a message to the topic without blocking, relying on
+// the underlying client's batching. The returned PublishResult resolves once
+// the batch containing the message has been sent; use Flush to wait for all
+// outstanding batches at once.
+func (t *Topic) PublishAsync(ctx context.Context, data []byte, attrs map[string]string) *PublishResult {
+	if t.t == nil {
+		return &PublishResult{err: errors.New("invalid topic")}
 	}
 
 	msg := &pubsub.Message{
@@ -110,8 +284,14 @@ func (t *Topic) Publish(ctx context.Context, data []byte, attrs map[string]strin
 		PublishTime: time.Now(),
 	}
 
-	result := t.t.Publish(ctx, msg)
-	msgID, err := result.Get(ctx)
+	return &PublishResult{r: t.t.Publish(ctx, msg)}
+}
+
+// Publish publishes a message to the topic with the given data and
+// attributes and blocks until it is sent. It returns the published message
+// ID or an error if the operation fails.
+func (t *Topic) Publish(ctx context.Context, data []byte, attrs map[string]string) (string, error) {
+	msgID, err := t.PublishAsync(ctx, data, attrs).Get(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to publish message: %w", err)
 	}
@@ -119,6 +299,46 @@ func (t *Topic) Publish(ctx context.Context, data []byte, attrs map[string]strin
 	return msgID, nil
 }
 
+// Flush blocks until all messages published on the topic, including those
+// still batched, have been sent.
+func (t *Topic) Flush(ctx context.Context) {
+	if t.t == nil {
+		return
+	}
+	t.t.Flush()
+}
+
+// PublishOrdered publishes a message with the given ordering key, enabling
+// message ordering on the topic if it isn't already enabled. Messages
+// published with the same key are delivered to subscribers in the order
+// they were published here, at the cost of throughput for that key.
+func (t *Topic) PublishOrdered(ctx context.Context, key string, data []byte, attrs map[string]string) *PublishResult {
+	if t.t == nil {
+		return &PublishResult{err: errors.New("invalid topic")}
+	}
+
+	t.enableOrdering.Do(func() { t.t.EnableMessageOrdering = true })
+
+	msg := &pubsub.Message{
+		Data:        data,
+		Attributes:  attrs,
+		PublishTime: time.Now(),
+		OrderingKey: key,
+	}
+
+	return &PublishResult{r: t.t.Publish(ctx, msg)}
+}
+
+// ResumePublish allows publishing for the given ordering key to resume after
+// a prior publish failure paused it, mirroring the upstream client's
+// per-key pause behavior on ordered topics.
+func (t *Topic) ResumePublish(key string) {
+	if t.t == nil {
+		return
+	}
+	t.t.ResumePublish(key)
+}
+
 // Stop stops the topic's publishing goroutines and releases resources.
 // It should be called when the topic is no longer needed.
 func (t *Topic) Stop() {
@@ -130,6 +350,12 @@ func (t *Topic) Stop() {
 // Subscription represents a Pub/Sub subscription and provides methods for receiving messages.
 type Subscription struct {
 	s *pubsub.Subscription
+
+	// dl is the dead-letter topic configured via DeadLetterPolicy, if any.
+	// Handlers that return ErrPermanent are published here directly, rather
+	// than waiting for Pub/Sub to dead-letter the message after
+	// MaxDeliveryAttempts.
+	dl *Topic
 }
 
 // Subscription returns a reference to a subscription with the given ID.
@@ -140,6 +366,49 @@ func (c *PubSubClient) Subscription(subID string) *Subscription {
 	return &Subscription{s: c.client.Subscription(subID)}
 }
 
+// deadLetter publishes msg to the subscription's configured dead-letter
+// topic, if any. Publish failures are logged rather than surfaced, since
+// Receive acks the message afterwards whether or not this publish succeeds.
+func (s *Subscription) deadLetter(ctx context.Context, msg *Message) {
+	if s.dl == nil {
+		return
+	}
+	if _, err := s.dl.Publish(ctx, msg.Data, msg.Attributes); err != nil {
+		slog.Error("failed to publish message to dead-letter topic",
+			"subscription", s.ID(),
+			"error", err,
+		)
+	}
+}
+
+// orderingState tracks, for a single Receive call, a per-key mutex so
+// handler invocations for the same ordering key never run concurrently.
+type orderingState struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func newOrderingState() *orderingState {
+	return &orderingState{
+		locks: make(map[string]*sync.Mutex),
+	}
+}
+
+// lock acquires the per-key mutex for key, creating it if necessary, and
+// returns a function that releases it.
+func (o *orderingState) lock(key string) func() {
+	o.mu.Lock()
+	l, ok := o.locks[key]
+	if !ok {
+		l = &sync.Mutex{}
+		o.locks[key] = l
+	}
+	o.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
 // ID returns the ID of the subscription.
 func (s *Subscription) ID() string {
 	if s.s == nil {
@@ -176,6 +445,61 @@ type ReceiveConfig struct {
 	// MaxOutstandingBytes is the maximum size of unprocessed messages.
 	// If not set, defaults to 1e9 (1GB).
 	MaxOutstandingBytes int
+
+	// RestartPolicy controls whether Receive transparently restarts the
+	// underlying streaming pull after a transient error instead of returning
+	// it to the caller. If nil or Enabled is false, Receive behaves as
+	// before and surfaces every error.
+	RestartPolicy *RestartPolicy
+
+	// MetricsSink, if set, is notified of permanent handler failures
+	// (Handler returning ErrPermanent) so operators can alert on them.
+	MetricsSink MetricsSink
+}
+
+// RestartPolicy configures automatic restart of a Subscription's streaming
+// pull when it terminates with a transient error.
+type RestartPolicy struct {
+	// Enabled turns on automatic restart of a failed Receive call.
+	Enabled bool
+
+	// InitialDelay is the delay before the first restart attempt.
+	// If not set, defaults to 1 second.
+	InitialDelay time.Duration
+
+	// MaxDelay caps the backoff delay between restart attempts.
+	// If not set, defaults to 1 minute.
+	MaxDelay time.Duration
+
+	// Multiplier is applied to the delay after each failed attempt.
+	// If not set (or <= 1), defaults to 2.
+	Multiplier float64
+
+	// MaxAttempts is the maximum number of consecutive restart attempts
+	// before Receive gives up and returns the last error. Zero means retry
+	// indefinitely.
+	MaxAttempts int
+
+	// ResetAfter is the minimum duration a stream must stay up before the
+	// backoff delay and attempt counter are reset to their initial values.
+	// If not set, defaults to 1 minute.
+	ResetAfter time.Duration
+
+	// IsPermanent classifies an error as unrecoverable, in which case
+	// Receive returns it immediately instead of restarting. If nil,
+	// DefaultIsPermanent is used.
+	IsPermanent func(error) bool
+}
+
+// DefaultIsPermanent reports whether err carries a gRPC status code that
+// should stop the receive loop for good rather than trigger a restart.
+func DefaultIsPermanent(err error) bool {
+	switch status.Code(err) {
+	case codes.ResourceExhausted, codes.PermissionDenied, codes.Unauthenticated, codes.NotFound, codes.FailedPrecondition:
+		return true
+	default:
+		return false
+	}
 }
 
 // Receive starts receiving messages and calls the handler for each message.
@@ -207,33 +531,148 @@ func (s *Subscription) Receive(ctx context.Context, handler Handler, cfg *Receiv
 		sub.ReceiveSettings.MaxOutstandingBytes = cfg.MaxOutstandingBytes
 	}
 
-	// Start receiving messages
-	return sub.Receive(ctx, func(ctx context.Context, m *pubsub.Message) {
-		// Recover from panics in the message handler
-		defer func() {
-			if r := recover(); r != nil {
-				// Log the panic and stack trace
-				fmt.Printf("panic in message handler: %v\n%s\n", r, string(debug.Stack()))
-				m.Nack() // Nack the message to be retried
+	var ordering *orderingState
+	if cfg.EnableMessageOrdering {
+		ordering = newOrderingState()
+	}
+
+	receiveOnce := func(ctx context.Context) error {
+		return sub.Receive(ctx, func(ctx context.Context, m *pubsub.Message) {
+			// Recover from panics in the message handler
+			defer func() {
+				if r := recover(); r != nil {
+					// Log the panic and stack trace
+					fmt.Printf("panic in message handler: %v\n%s\n", r, string(debug.Stack()))
+					m.Nack() // Nack the message to be retried
+				}
+			}()
+
+			// Serialize handler invocations per ordering key so that, within
+			// a key, messages are processed one at a time and in the order
+			// Pub/Sub delivers them; unrelated keys still run concurrently.
+			if ordering != nil && m.OrderingKey != "" {
+				unlock := ordering.lock(m.OrderingKey)
+				defer unlock()
 			}
-		}()
-
-		msg := &Message{
-			ID:              m.ID,
-			Data:            m.Data,
-			Attributes:      m.Attributes,
-			PublishTime:     m.PublishTime,
-			DeliveryAttempt: *m.DeliveryAttempt,
+
+			var deliveryAttempt int
+			if m.DeliveryAttempt != nil {
+				// Only set by Pub/Sub when the subscription has a dead-letter
+				// policy configured.
+				deliveryAttempt = *m.DeliveryAttempt
+			}
+
+			msg := &Message{
+				ID:              m.ID,
+				Data:            m.Data,
+				Attributes:      m.Attributes,
+				PublishTime:     m.PublishTime,
+				DeliveryAttempt: deliveryAttempt,
+				OrderingKey:     m.OrderingKey,
+			}
+
+			// Call the handler and classify the result
+			err := handler(ctx, msg)
+			switch {
+			case err == nil:
+				m.Ack() // Acknowledge successful processing
+
+			case errors.Is(err, ErrDrop):
+				m.Ack() // Acknowledge and discard without dead-lettering
+
+			case errors.Is(err, ErrPermanent):
+				s.deadLetter(ctx, msg)
+				if cfg.MetricsSink != nil {
+					cfg.MetricsSink.IncPermanentFailures(s.ID(), 1)
+				}
+				m.Ack() // Routed to the dead-letter topic; don't retry
+
+			default:
+				// ErrRetry and any other error fall back to nack/redelivery.
+				// Pub/Sub itself pauses delivery of subsequent messages for
+				// this ordering key until the current one is acked.
+				m.Nack()
+			}
+		})
+	}
+
+	if cfg.RestartPolicy == nil || !cfg.RestartPolicy.Enabled {
+		return receiveOnce(ctx)
+	}
+
+	return receiveWithRestart(ctx, cfg.RestartPolicy, receiveOnce)
+}
+
+// receiveWithRestart runs receiveOnce in a loop, restarting it with
+// exponential backoff after transient errors until the context is done, a
+// permanent error is classified by the policy, or MaxAttempts is exhausted.
+func receiveWithRestart(ctx context.Context, policy *RestartPolicy, receiveOnce func(context.Context) error) error {
+	initialDelay := policy.InitialDelay
+	if initialDelay <= 0 {
+		initialDelay = time.Second
+	}
+
+	maxDelay := policy.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = time.Minute
+	}
+
+	multiplier := policy.Multiplier
+	if multiplier <= 1 {
+		multiplier = 2
+	}
+
+	resetAfter := policy.ResetAfter
+	if resetAfter <= 0 {
+		resetAfter = time.Minute
+	}
+
+	isPermanent := policy.IsPermanent
+	if isPermanent == nil {
+		isPermanent = DefaultIsPermanent
+	}
+
+	delay := initialDelay
+	attempt := 0
+
+	for {
+		start := time.Now()
+		err := receiveOnce(ctx)
+		if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+
+		if isPermanent(err) {
+			return err
+		}
+
+		if time.Since(start) >= resetAfter {
+			delay = initialDelay
+			attempt = 0
+		}
+
+		attempt++
+		if policy.MaxAttempts > 0 && attempt > policy.MaxAttempts {
+			return fmt.Errorf("giving up after %d restart attempts: %w", policy.MaxAttempts, err)
 		}
 
-		// Call the handler and handle the result
-		if err := handler(ctx, msg); err != nil {
-			m.Nack() // Negative acknowledgment - message will be retried
-			return
+		slog.Warn("restarting pub/sub receiver after transient error",
+			"attempt", attempt,
+			"delay", delay,
+			"error", err,
+		)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
 		}
 
-		m.Ack() // Acknowledge successful processing
-	})
+		delay = time.Duration(float64(delay) * multiplier)
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
 }
 
 // Delete removes the subscription from the project.
@@ -265,6 +704,31 @@ type SubscriptionConfig struct {
 	// subscription was created. If false, it will receive all messages retained
 	// in the topic's backlog.
 	StartAtTime time.Time
+
+	// DeadLetterPolicy, if set, forwards messages Pub/Sub cannot deliver
+	// after MaxDeliveryAttempts to a separate topic instead of retrying
+	// forever. It also backs Receive's handling of Handler-returned
+	// ErrPermanent.
+	DeadLetterPolicy *DeadLetterPolicy
+
+	// EnableMessageOrdering creates the subscription with ordered delivery
+	// enabled, so messages published with the same ordering key are
+	// delivered to Receive in the order they were published. The topic must
+	// also have ordering enabled (see Topic.PublishOrdered).
+	EnableMessageOrdering bool
+}
+
+// DeadLetterPolicy configures automatic dead-lettering of messages that
+// cannot be processed.
+type DeadLetterPolicy struct {
+	// DeadLetterTopic receives messages that exceed MaxDeliveryAttempts, or
+	// that a Handler explicitly routes there by returning ErrPermanent.
+	DeadLetterTopic *Topic
+
+	// MaxDeliveryAttempts is the number of delivery attempts Pub/Sub will
+	// make before forwarding a message to DeadLetterTopic. If not set,
+	// defaults to 5.
+	MaxDeliveryAttempts int
 }
 
 // CreateSubscription creates a new subscription to the specified topic.
@@ -324,6 +788,22 @@ func (c *PubSubClient) CreateSubscription(
 				MaximumBackoff: maxBackoff,
 			}
 		}
+
+		if cfg.DeadLetterPolicy != nil && cfg.DeadLetterPolicy.DeadLetterTopic != nil && cfg.DeadLetterPolicy.DeadLetterTopic.t != nil {
+			maxAttempts := cfg.DeadLetterPolicy.MaxDeliveryAttempts
+			if maxAttempts <= 0 {
+				maxAttempts = 5
+			}
+
+			subConfig.DeadLetterPolicy = &pubsub.DeadLetterPolicy{
+				DeadLetterTopic:     cfg.DeadLetterPolicy.DeadLetterTopic.t.String(),
+				MaxDeliveryAttempts: maxAttempts,
+			}
+		}
+
+		if cfg.EnableMessageOrdering {
+			subConfig.EnableMessageOrdering = true
+		}
 	}
 
 	// Create the subscription
@@ -332,7 +812,12 @@ func (c *PubSubClient) CreateSubscription(
 		return nil, fmt.Errorf("failed to create subscription: %w", err)
 	}
 
-	return &Subscription{s: s}, nil
+	created := &Subscription{s: s}
+	if cfg != nil && cfg.DeadLetterPolicy != nil {
+		created.dl = cfg.DeadLetterPolicy.DeadLetterTopic
+	}
+
+	return created, nil
 }
 
 // ListSubscriptions returns a list of all subscriptions in the project.
@@ -0,0 +1,357 @@
+package gcppubsub_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gcppubsub "gcp_lib/lib_pubsub"
+	fakepubsub "gcp_lib/lib_pubsub/pstest"
+)
+
+func TestDefaultIsPermanent(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"resource exhausted", status.Error(codes.ResourceExhausted, "quota"), true},
+		{"permission denied", status.Error(codes.PermissionDenied, "no"), true},
+		{"unauthenticated", status.Error(codes.Unauthenticated, "no"), true},
+		{"not found", status.Error(codes.NotFound, "no"), true},
+		{"failed precondition", status.Error(codes.FailedPrecondition, "no"), true},
+		{"unavailable", status.Error(codes.Unavailable, "retry"), false},
+		{"plain error", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gcppubsub.DefaultIsPermanent(tt.err); got != tt.want {
+				t.Errorf("DefaultIsPermanent(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// newTestClient starts a fake server and returns a client bound to it,
+// registering cleanup with t.
+func newTestClient(t *testing.T) (*fakepubsub.Server, *gcppubsub.PubSubClient) {
+	t.Helper()
+
+	srv, err := fakepubsub.NewServer("test-project")
+	if err != nil {
+		t.Fatalf("fakepubsub.NewServer() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = srv.Close() })
+
+	client, err := srv.Client(context.Background())
+	if err != nil {
+		t.Fatalf("srv.Client() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	return srv, client
+}
+
+func TestTopicPublishAndExists(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  []byte
+		attrs map[string]string
+	}{
+		{"no attributes", []byte("hello"), nil},
+		{"with attributes", []byte("world"), map[string]string{"key": "value"}},
+	}
+
+	srv, client := newTestClient(t)
+	ctx := context.Background()
+
+	if err := srv.CreateTopic("orders"); err != nil {
+		t.Fatalf("srv.CreateTopic() failed: %v", err)
+	}
+	topic := client.Topic("orders")
+
+	if exists, err := topic.Exists(ctx); err != nil || !exists {
+		t.Fatalf("topic.Exists() = %v, %v; want true, nil", exists, err)
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			msgID, err := topic.Publish(ctx, tt.data, tt.attrs)
+			if err != nil {
+				t.Fatalf("Publish() failed: %v", err)
+			}
+			if msgID == "" {
+				t.Error("Publish() returned an empty message ID")
+			}
+		})
+	}
+}
+
+func TestSubscriptionCreateAndReceiveDeliversMessage(t *testing.T) {
+	srv, client := newTestClient(t)
+	ctx := context.Background()
+
+	if err := srv.CreateTopic("orders"); err != nil {
+		t.Fatalf("srv.CreateTopic() failed: %v", err)
+	}
+	topic := client.Topic("orders")
+
+	sub, err := client.CreateSubscription(ctx, "orders-worker", topic, &gcppubsub.SubscriptionConfig{
+		AckDeadline: 10 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("CreateSubscription() failed: %v", err)
+	}
+
+	if _, err := topic.Publish(ctx, []byte("payload"), nil); err != nil {
+		t.Fatalf("Publish() failed: %v", err)
+	}
+
+	receiveCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	received := make(chan *gcppubsub.Message, 1)
+	go func() {
+		_ = sub.Receive(receiveCtx, func(_ context.Context, msg *gcppubsub.Message) error {
+			received <- msg
+			cancel()
+			return nil
+		}, nil)
+	}()
+
+	select {
+	case msg := <-received:
+		if string(msg.Data) != "payload" {
+			t.Errorf("received data = %q, want %q", msg.Data, "payload")
+		}
+	case <-receiveCtx.Done():
+		t.Fatal("timed out waiting for message")
+	}
+}
+
+func TestTopicPublishAsyncAndFlush(t *testing.T) {
+	srv, client := newTestClient(t)
+	ctx := context.Background()
+
+	if err := srv.CreateTopic("orders"); err != nil {
+		t.Fatalf("srv.CreateTopic() failed: %v", err)
+	}
+	topic := client.Topic("orders")
+
+	results := make([]*gcppubsub.PublishResult, 3)
+	for i := range results {
+		results[i] = topic.PublishAsync(ctx, []byte(fmt.Sprintf("msg-%d", i)), nil)
+	}
+
+	topic.Flush(ctx)
+
+	for i, r := range results {
+		if _, err := r.Get(ctx); err != nil {
+			t.Errorf("results[%d].Get() failed: %v", i, err)
+		}
+	}
+}
+
+func TestSubscriptionDropsMessageOnErrDrop(t *testing.T) {
+	srv, client := newTestClient(t)
+	ctx := context.Background()
+
+	if err := srv.CreateTopic("orders"); err != nil {
+		t.Fatalf("srv.CreateTopic() failed: %v", err)
+	}
+	topic := client.Topic("orders")
+
+	sub, err := client.CreateSubscription(ctx, "orders-worker", topic, nil)
+	if err != nil {
+		t.Fatalf("CreateSubscription() failed: %v", err)
+	}
+
+	if _, err := topic.Publish(ctx, []byte("payload"), nil); err != nil {
+		t.Fatalf("Publish() failed: %v", err)
+	}
+
+	// ErrDrop must ack without redelivery, so after Receive returns (on
+	// context deadline, since nothing ever cancels it early) the handler
+	// should have been called exactly once.
+	receiveCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	var calls int32
+	_ = sub.Receive(receiveCtx, func(_ context.Context, msg *gcppubsub.Message) error {
+		atomic.AddInt32(&calls, 1)
+		return gcppubsub.ErrDrop
+	}, nil)
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("handler called %d times, want exactly 1", got)
+	}
+}
+
+// countingSink is a MetricsSink that records IncPermanentFailures calls.
+type countingSink struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func newCountingSink() *countingSink {
+	return &countingSink{counts: make(map[string]int64)}
+}
+
+func (c *countingSink) IncPermanentFailures(subID string, delta int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[subID] += delta
+}
+
+func (c *countingSink) get(subID string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[subID]
+}
+
+func TestSubscriptionDeadLettersOnErrPermanent(t *testing.T) {
+	srv, client := newTestClient(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"orders", "orders-dlq"} {
+		if err := srv.CreateTopic(id); err != nil {
+			t.Fatalf("srv.CreateTopic(%q) failed: %v", id, err)
+		}
+	}
+	topic := client.Topic("orders")
+	dlTopic := client.Topic("orders-dlq")
+
+	dlSub, err := client.CreateSubscription(ctx, "orders-dlq-worker", dlTopic, nil)
+	if err != nil {
+		t.Fatalf("CreateSubscription(dlq) failed: %v", err)
+	}
+
+	sink := newCountingSink()
+	sub, err := client.CreateSubscription(ctx, "orders-worker", topic, &gcppubsub.SubscriptionConfig{
+		DeadLetterPolicy: &gcppubsub.DeadLetterPolicy{DeadLetterTopic: dlTopic},
+	})
+	if err != nil {
+		t.Fatalf("CreateSubscription() failed: %v", err)
+	}
+
+	if _, err := topic.Publish(ctx, []byte("poison"), nil); err != nil {
+		t.Fatalf("Publish() failed: %v", err)
+	}
+
+	receiveCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	go func() {
+		_ = sub.Receive(receiveCtx, func(_ context.Context, msg *gcppubsub.Message) error {
+			return gcppubsub.ErrPermanent
+		}, &gcppubsub.ReceiveConfig{MetricsSink: sink})
+	}()
+
+	dlReceiveCtx, dlCancel := context.WithTimeout(ctx, 10*time.Second)
+	defer dlCancel()
+
+	received := make(chan *gcppubsub.Message, 1)
+	go func() {
+		_ = dlSub.Receive(dlReceiveCtx, func(_ context.Context, msg *gcppubsub.Message) error {
+			received <- msg
+			dlCancel()
+			return nil
+		}, nil)
+	}()
+
+	select {
+	case msg := <-received:
+		if string(msg.Data) != "poison" {
+			t.Errorf("dead-lettered data = %q, want %q", msg.Data, "poison")
+		}
+	case <-dlReceiveCtx.Done():
+		t.Fatal("timed out waiting for message on dead-letter topic")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if got := sink.get("orders-worker"); got == 1 {
+			break
+		} else if time.Now().After(deadline) {
+			t.Errorf("MetricsSink IncPermanentFailures(%q) count = %d, want 1", "orders-worker", got)
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSubscriptionOrderingSerializesHandlerCalls(t *testing.T) {
+	srv, client := newTestClient(t)
+	ctx := context.Background()
+
+	if err := srv.CreateTopic("orders"); err != nil {
+		t.Fatalf("srv.CreateTopic() failed: %v", err)
+	}
+	topic := client.Topic("orders")
+
+	sub, err := client.CreateSubscription(ctx, "orders-worker", topic, &gcppubsub.SubscriptionConfig{
+		EnableMessageOrdering: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateSubscription() failed: %v", err)
+	}
+
+	const n = 5
+	for i := 0; i < n; i++ {
+		if _, err := topic.PublishOrdered(ctx, "same-key", []byte(fmt.Sprintf("%d", i)), nil).Get(ctx); err != nil {
+			t.Fatalf("PublishOrdered() failed: %v", err)
+		}
+	}
+
+	receiveCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	var order []string
+	var inHandler int32
+
+	go func() {
+		_ = sub.Receive(receiveCtx, func(_ context.Context, msg *gcppubsub.Message) error {
+			if atomic.AddInt32(&inHandler, 1) != 1 {
+				t.Errorf("handler ran concurrently for ordering key %q", msg.OrderingKey)
+			}
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			order = append(order, string(msg.Data))
+			done := len(order) == n
+			mu.Unlock()
+
+			atomic.AddInt32(&inHandler, -1)
+			if done {
+				cancel()
+			}
+			return nil
+		}, &gcppubsub.ReceiveConfig{
+			Concurrency:           4,
+			EnableMessageOrdering: true,
+		})
+	}()
+
+	<-receiveCtx.Done()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != n {
+		t.Fatalf("received %d messages, want %d", len(order), n)
+	}
+	for i, data := range order {
+		if data != fmt.Sprintf("%d", i) {
+			t.Errorf("order[%d] = %q, want %q", i, data, fmt.Sprintf("%d", i))
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package gcppubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// These exercise receiveWithRestart's backoff/give-up logic directly against
+// a fake receiveOnce, rather than through a real or fake Pub/Sub server:
+// injecting a bounded number of transient RPC failures doesn't reliably
+// reach this loop, since the underlying client library retries those on its
+// own first.
+
+func TestReceiveWithRestartRetriesTransientErrors(t *testing.T) {
+	transient := errors.New("transient")
+	attempts := 0
+
+	err := receiveWithRestart(context.Background(), &RestartPolicy{
+		InitialDelay: time.Millisecond,
+		MaxDelay:     time.Millisecond,
+	}, func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return transient
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("receiveWithRestart() = %v, want nil", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestReceiveWithRestartStopsOnPermanentError(t *testing.T) {
+	permanent := errors.New("permanent")
+	attempts := 0
+
+	err := receiveWithRestart(context.Background(), &RestartPolicy{
+		InitialDelay: time.Millisecond,
+		IsPermanent:  func(err error) bool { return errors.Is(err, permanent) },
+	}, func(ctx context.Context) error {
+		attempts++
+		return permanent
+	})
+
+	if !errors.Is(err, permanent) {
+		t.Errorf("err = %v, want %v", err, permanent)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestReceiveWithRestartGivesUpAfterMaxAttempts(t *testing.T) {
+	transient := errors.New("transient")
+	attempts := 0
+
+	err := receiveWithRestart(context.Background(), &RestartPolicy{
+		InitialDelay: time.Millisecond,
+		MaxDelay:     time.Millisecond,
+		MaxAttempts:  2,
+	}, func(ctx context.Context) error {
+		attempts++
+		return transient
+	})
+
+	if err == nil {
+		t.Fatal("receiveWithRestart() = nil, want an error after exhausting MaxAttempts")
+	}
+	// MaxAttempts counts restarts, so the loop still makes one call beyond it
+	// before giving up.
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestReceiveWithRestartRespectsContextCancellation(t *testing.T) {
+	transient := errors.New("transient")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := receiveWithRestart(ctx, &RestartPolicy{
+		InitialDelay: time.Hour, // long enough that only cancellation unblocks this
+	}, func(ctx context.Context) error {
+		cancel()
+		return transient
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,145 @@
+// Package pstest provides an in-process fake Pub/Sub server for tests. It
+// wraps cloud.google.com/go/pubsub/pstest behind the same PubSubClient type
+// gcp_lib/lib_pubsub exposes, so the wrapper can be exercised without a real
+// GCP project.
+package pstest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	pb "cloud.google.com/go/pubsub/apiv1/pubsubpb"
+	"cloud.google.com/go/pubsub/pstest"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+
+	gcppubsub "gcp_lib/lib_pubsub"
+)
+
+// Server is an in-process fake Pub/Sub server. Its state is held in memory;
+// clients reach it through a gRPC connection to the fake's local address, not
+// through an in-memory transport.
+type Server struct {
+	srv       *pstest.Server
+	conn      *grpc.ClientConn
+	projectID string
+	pullErr   *errorReactor
+}
+
+// NewServer starts a fake Pub/Sub server for projectID, listening on a local
+// address, and dials it over an insecure gRPC connection to that address.
+// Callers must call Close when done, typically
+// via t.Cleanup.
+func NewServer(projectID string) (*Server, error) {
+	reactor := &errorReactor{}
+
+	// gcppubsub.Subscription.Receive always sets ReceiveSettings.Synchronous,
+	// so the client issues unary Pull RPCs rather than StreamingPull; that's
+	// the only RPC the fake's reactor system invokes for message delivery.
+	srv := pstest.NewServer(pstest.ServerReactorOption{
+		FuncName: "Pull",
+		Reactor:  reactor,
+	})
+
+	conn, err := grpc.Dial(srv.Addr, grpc.WithInsecure())
+	if err != nil {
+		srv.Close()
+		return nil, err
+	}
+
+	return &Server{srv: srv, conn: conn, projectID: projectID, pullErr: reactor}, nil
+}
+
+// Close shuts down the fake server and the connection to it.
+func (s *Server) Close() error {
+	_ = s.conn.Close()
+	return s.srv.Close()
+}
+
+// Client returns a PubSubClient bound to the fake server.
+func (s *Server) Client(ctx context.Context) (*gcppubsub.PubSubClient, error) {
+	return gcppubsub.NewPubSubClientWithOptions(ctx, s.projectID,
+		option.WithGRPCConn(s.conn),
+		option.WithoutAuthentication(),
+	)
+}
+
+// topicName returns the fully-qualified resource name for topicID, which is
+// what the fake server requires internally.
+func (s *Server) topicName(topicID string) string {
+	return fmt.Sprintf("projects/%s/topics/%s", s.projectID, topicID)
+}
+
+// subscriptionName returns the fully-qualified resource name for subID.
+func (s *Server) subscriptionName(subID string) string {
+	return fmt.Sprintf("projects/%s/subscriptions/%s", s.projectID, subID)
+}
+
+// CreateTopic creates a topic directly on the fake server, for test setup
+// that doesn't want to exercise a client's own topic-creation path.
+func (s *Server) CreateTopic(topicID string) error {
+	_, err := s.srv.GServer.CreateTopic(context.Background(), &pb.Topic{Name: s.topicName(topicID)})
+	return err
+}
+
+// CreateSubscription creates a subscription to topicID directly on the fake
+// server, for test setup that doesn't want to exercise
+// PubSubClient.CreateSubscription.
+func (s *Server) CreateSubscription(subID, topicID string) error {
+	_, err := s.srv.GServer.CreateSubscription(context.Background(), &pb.Subscription{
+		Name:  s.subscriptionName(subID),
+		Topic: s.topicName(topicID),
+	})
+	return err
+}
+
+// Publish publishes a message directly on the fake server, without going
+// through a client, and returns the assigned message ID.
+func (s *Server) Publish(topicID string, data []byte, attrs map[string]string) string {
+	return s.srv.Publish(s.topicName(topicID), data, attrs)
+}
+
+// Messages returns the fake server's view of every message published so
+// far, including delivery and ack counts. The fake does not segment this
+// state by subscription, so subID is accepted for symmetry with
+// gcppubsub.Subscription but does not currently filter the result.
+func (s *Server) Messages(subID string) []*pstest.Message {
+	return s.srv.Messages()
+}
+
+// SetStreamingPullError forces the next count Pull RPCs to fail with err
+// instead of reaching the fake's normal delivery logic. Note that the
+// underlying client library retries bounded transient errors (including
+// Unavailable) on its own before Subscription.Receive ever observes a
+// failure, so a small count may be absorbed there and never reach a
+// configured RestartPolicy; prefer exercising the restart loop directly for
+// that.
+func (s *Server) SetStreamingPullError(err error, count int) {
+	s.pullErr.set(err, count)
+}
+
+// errorReactor injects err into the next count matching RPCs, then lets
+// subsequent calls proceed normally.
+type errorReactor struct {
+	mu    sync.Mutex
+	count int
+	err   error
+}
+
+func (r *errorReactor) set(err error, count int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = err
+	r.count = count
+}
+
+func (r *errorReactor) React(_ interface{}) (bool, interface{}, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.count <= 0 {
+		return false, nil, nil
+	}
+	r.count--
+	return true, nil, r.err
+}
@@ -0,0 +1,289 @@
+// Package libstorage provides a high-level wrapper around the Google Cloud
+// Storage client with improved error handling, resource management, and
+// conventions consistent with gcp_lib/lib_pubsub.
+package libstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+
+	appcontext "gcp_lib/appcontext"
+)
+
+var (
+	// ErrBucketExists is returned when attempting to create a bucket that already exists
+	ErrBucketExists = errors.New("bucket already exists")
+	// ErrObjectNotFound is returned when a specified object does not exist
+	ErrObjectNotFound = errors.New("object not found")
+)
+
+// StorageClient is the main client for interacting with Google Cloud Storage.
+// It provides a simplified interface for common operations.
+type StorageClient struct {
+	client    *storage.Client
+	projectID string
+}
+
+// NewStorageClient creates a new Storage client for the project configured
+// in appCtx's loaded service account, rather than a hard-coded project ID.
+// The client should be closed after use using the Close() method.
+func NewStorageClient(appCtx *appcontext.AppContext) (*StorageClient, error) {
+	if appCtx == nil || appCtx.Config == nil || appCtx.Config.SA.ProjectID == "" {
+		return nil, errors.New("project ID cannot be empty")
+	}
+
+	client, err := storage.NewClient(appCtx.Ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage client: %w", err)
+	}
+
+	return &StorageClient{client: client, projectID: appCtx.Config.SA.ProjectID}, nil
+}
+
+// Close releases any resources held by the StorageClient.
+// It should be called when the client is no longer needed.
+func (c *StorageClient) Close() error {
+	if c.client == nil {
+		return nil
+	}
+	return c.client.Close()
+}
+
+// Bucket represents a Cloud Storage bucket and provides methods for working
+// with the objects inside it.
+type Bucket struct {
+	b    *storage.BucketHandle
+	name string
+}
+
+// Bucket returns a reference to a bucket with the given name.
+func (c *StorageClient) Bucket(name string) *Bucket {
+	if name == "" {
+		return &Bucket{}
+	}
+	return &Bucket{b: c.client.Bucket(name), name: name}
+}
+
+// Name returns the bucket's name.
+func (b *Bucket) Name() string {
+	return b.name
+}
+
+// LifecycleRule deletes objects once they reach AgeInDays since creation.
+type LifecycleRule struct {
+	// AgeInDays is the number of days after object creation at which the
+	// object is deleted.
+	AgeInDays int64
+}
+
+// BucketConfig holds configuration options for creating a bucket.
+type BucketConfig struct {
+	// Location is the bucket's storage location, e.g. "US".
+	// If not set, the default multi-region location is used.
+	Location string
+
+	// StorageClass is the default storage class for objects in the bucket,
+	// e.g. "STANDARD", "NEARLINE". If not set, "STANDARD" is used.
+	StorageClass string
+
+	// LifecycleRules configures automatic deletion of aging objects.
+	LifecycleRules []LifecycleRule
+
+	// Timeout bounds how long CreateBucket waits for the operation to
+	// complete. If not set, defaults to 30 seconds.
+	Timeout time.Duration
+}
+
+// CreateBucket creates a new bucket with the given name.
+// Returns ErrBucketExists if the bucket already exists.
+func (c *StorageClient) CreateBucket(ctx context.Context, name string, cfg *BucketConfig) (*Bucket, error) {
+	if name == "" {
+		return nil, errors.New("bucket name cannot be empty")
+	}
+
+	timeout := 30 * time.Second
+	if cfg != nil && cfg.Timeout > 0 {
+		timeout = cfg.Timeout
+	}
+	opCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	bkt := c.client.Bucket(name)
+
+	if _, err := bkt.Attrs(opCtx); err == nil {
+		return nil, ErrBucketExists
+	} else if !errors.Is(err, storage.ErrBucketNotExist) {
+		return nil, fmt.Errorf("failed to check bucket existence: %w", err)
+	}
+
+	attrs := &storage.BucketAttrs{}
+	if cfg != nil {
+		attrs.Location = cfg.Location
+
+		if cfg.StorageClass != "" {
+			attrs.StorageClass = cfg.StorageClass
+		}
+
+		for _, rule := range cfg.LifecycleRules {
+			attrs.Lifecycle.Rules = append(attrs.Lifecycle.Rules, storage.LifecycleRule{
+				Action:    storage.LifecycleAction{Type: storage.DeleteAction},
+				Condition: storage.LifecycleCondition{AgeInDays: rule.AgeInDays},
+			})
+		}
+	}
+
+	if err := bkt.Create(opCtx, c.projectID, attrs); err != nil {
+		return nil, fmt.Errorf("failed to create bucket: %w", err)
+	}
+
+	return &Bucket{b: bkt, name: name}, nil
+}
+
+// DeleteBucket deletes the named bucket. The bucket must be empty.
+func (c *StorageClient) DeleteBucket(ctx context.Context, name string) error {
+	if name == "" {
+		return errors.New("bucket name cannot be empty")
+	}
+	return c.client.Bucket(name).Delete(ctx)
+}
+
+// ListBuckets returns the names of all buckets in the project.
+func (c *StorageClient) ListBuckets(ctx context.Context) ([]string, error) {
+	var names []string
+
+	it := c.client.Buckets(ctx, c.projectID)
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list buckets: %w", err)
+		}
+		names = append(names, attrs.Name)
+	}
+
+	return names, nil
+}
+
+// Object represents an object within a bucket.
+type Object struct {
+	o *storage.ObjectHandle
+}
+
+// Object returns a reference to an object with the given name in the bucket.
+func (b *Bucket) Object(name string) *Object {
+	if b.b == nil || name == "" {
+		return &Object{}
+	}
+	return &Object{o: b.b.Object(name)}
+}
+
+// ObjectAttrs holds metadata applied to an object on upload.
+type ObjectAttrs struct {
+	// ContentType is the object's MIME type, e.g. "application/json".
+	ContentType string
+
+	// Metadata holds arbitrary user-provided key-value metadata.
+	Metadata map[string]string
+
+	// ChunkSize controls the size, in bytes, of each resumable upload
+	// chunk. If not set, the client's default chunk size is used.
+	ChunkSize int
+}
+
+// UploadObject streams the contents of r into the object with the given
+// name using a resumable upload, and returns the number of bytes written.
+func (b *Bucket) UploadObject(ctx context.Context, name string, r io.Reader, attrs ObjectAttrs) (written int64, err error) {
+	if b.b == nil || name == "" {
+		return 0, errors.New("invalid bucket or object name")
+	}
+
+	// Recover from panics while streaming the upload so a bad reader can't
+	// take down the caller.
+	defer func() {
+		if rec := recover(); rec != nil {
+			fmt.Printf("panic uploading object %q: %v\n%s\n", name, rec, string(debug.Stack()))
+			err = fmt.Errorf("panic during upload of %q: %v", name, rec)
+		}
+	}()
+
+	w := b.b.Object(name).NewWriter(ctx)
+	w.ContentType = attrs.ContentType
+	w.Metadata = attrs.Metadata
+	if attrs.ChunkSize > 0 {
+		w.ChunkSize = attrs.ChunkSize
+	}
+
+	n, copyErr := io.Copy(w, r)
+	if copyErr != nil {
+		_ = w.Close()
+		return n, fmt.Errorf("failed to upload object %q: %w", name, copyErr)
+	}
+
+	if closeErr := w.Close(); closeErr != nil {
+		return n, fmt.Errorf("failed to finalize upload of object %q: %w", name, closeErr)
+	}
+
+	return n, nil
+}
+
+// DownloadObject returns a reader for the object's contents. The caller must
+// Close the reader when done. Returns ErrObjectNotFound if the object does
+// not exist.
+func (b *Bucket) DownloadObject(ctx context.Context, name string) (io.ReadCloser, error) {
+	if b.b == nil || name == "" {
+		return nil, errors.New("invalid bucket or object name")
+	}
+
+	r, err := b.b.Object(name).NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to download object %q: %w", name, err)
+	}
+
+	return r, nil
+}
+
+// SignedURLOptions configures a generated signed URL.
+type SignedURLOptions struct {
+	// Method is the HTTP method the URL is valid for, e.g. "GET", "PUT".
+	// If not set, defaults to "GET".
+	Method string
+
+	// Expires is how long the URL remains valid. If not set, defaults to
+	// 15 minutes.
+	Expires time.Duration
+}
+
+// SignedURL returns a signed URL granting temporary access to the named
+// object.
+func (b *Bucket) SignedURL(name string, opts SignedURLOptions) (string, error) {
+	if b.b == nil || name == "" {
+		return "", errors.New("invalid bucket or object name")
+	}
+
+	method := opts.Method
+	if method == "" {
+		method = "GET"
+	}
+
+	expires := opts.Expires
+	if expires <= 0 {
+		expires = 15 * time.Minute
+	}
+
+	return b.b.SignedURL(name, &storage.SignedURLOptions{
+		Method:  method,
+		Expires: time.Now().Add(expires),
+	})
+}